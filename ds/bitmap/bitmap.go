@@ -0,0 +1,96 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const wordBits = 64
+
+// Bitmap is a fixed-size array of bits backed by a slice of uint64 words
+type Bitmap struct {
+	n     uint64
+	words []uint64
+}
+
+// New news a Bitmap that can hold n bits
+func New(n uint64) *Bitmap {
+	return &Bitmap{
+		n:     n,
+		words: make([]uint64, (n+wordBits-1)/wordBits),
+	}
+}
+
+// NewFromData news a Bitmap by data passed, the data was generated by function 'Data()'
+func NewFromData(data []byte) *Bitmap {
+	b := &Bitmap{}
+	reader := bytes.NewReader(data)
+	binary.Read(reader, binary.LittleEndian, &b.n)
+	b.words = make([]uint64, (b.n+wordBits-1)/wordBits)
+	binary.Read(reader, binary.LittleEndian, b.words)
+	return b
+}
+
+// Set sets the bit at position i to 1
+func (b *Bitmap) Set(i uint64) {
+	b.words[i/wordBits] |= 1 << (i % wordBits)
+}
+
+// Clear sets the bit at position i to 0
+func (b *Bitmap) Clear(i uint64) {
+	b.words[i/wordBits] &^= 1 << (i % wordBits)
+}
+
+// IsSet returns true if the bit at position i is 1
+func (b *Bitmap) IsSet(i uint64) bool {
+	return b.words[i/wordBits]&(1<<(i%wordBits)) != 0
+}
+
+// Len returns the number of bits the Bitmap holds
+func (b *Bitmap) Len() uint64 {
+	return b.n
+}
+
+// OrWith sets this Bitmap's words to the bitwise OR of its own words and other's words.
+// other must have the same length as b.
+func (b *Bitmap) OrWith(other *Bitmap) {
+	for i := range b.words {
+		b.words[i] |= other.words[i]
+	}
+}
+
+// AndWith sets this Bitmap's words to the bitwise AND of its own words and other's words.
+// other must have the same length as b.
+func (b *Bitmap) AndWith(other *Bitmap) {
+	for i := range b.words {
+		b.words[i] &= other.words[i]
+	}
+}
+
+// Equal returns true if b and other have the same length and the same bits set
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if b.n != other.n {
+		return false
+	}
+	for i := range b.words {
+		if b.words[i] != other.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of the Bitmap
+func (b *Bitmap) Copy() *Bitmap {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &Bitmap{n: b.n, words: words}
+}
+
+// Data returns the data of Bitmap, it can be used to new a Bitmap by using function 'NewFromData'.
+func (b *Bitmap) Data() []byte {
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, b.n)
+	binary.Write(buf, binary.LittleEndian, b.words)
+	return buf.Bytes()
+}
@@ -0,0 +1,89 @@
+package bitmap
+
+import "testing"
+
+func TestBitmap_SetClearIsSet(t *testing.T) {
+	b := New(128)
+
+	b.Set(3)
+	b.Set(100)
+	if !b.IsSet(3) || !b.IsSet(100) {
+		t.Fatal("expected bits 3 and 100 to be set")
+	}
+	if b.IsSet(4) {
+		t.Fatal("did not expect bit 4 to be set")
+	}
+
+	b.Clear(3)
+	if b.IsSet(3) {
+		t.Fatal("did not expect bit 3 to be set after Clear")
+	}
+	if !b.IsSet(100) {
+		t.Fatal("expected bit 100 to still be set")
+	}
+}
+
+func TestBitmap_DataRoundTrip(t *testing.T) {
+	b := New(128)
+	b.Set(3)
+	b.Set(100)
+
+	restored := NewFromData(b.Data())
+	if !restored.IsSet(3) || !restored.IsSet(100) {
+		t.Fatal("expected bits 3 and 100 to survive Data round trip")
+	}
+	if restored.IsSet(4) {
+		t.Fatal("did not expect bit 4 to be set after round trip")
+	}
+	if restored.Len() != b.Len() {
+		t.Fatalf("expected Len %d, got %d", b.Len(), restored.Len())
+	}
+}
+
+func TestBitmap_OrWithAndWith(t *testing.T) {
+	a := New(128)
+	a.Set(3)
+	b := New(128)
+	b.Set(100)
+
+	or := a.Copy()
+	or.OrWith(b)
+	if !or.IsSet(3) || !or.IsSet(100) {
+		t.Fatal("expected OrWith to set bits from both bitmaps")
+	}
+
+	and := a.Copy()
+	and.AndWith(b)
+	if and.IsSet(3) || and.IsSet(100) {
+		t.Fatal("did not expect AndWith of disjoint bitmaps to set either bit")
+	}
+
+	and.Set(3)
+	b.Set(3)
+	and2 := a.Copy()
+	and2.AndWith(b)
+	if !and2.IsSet(3) {
+		t.Fatal("expected AndWith to keep bits set in both bitmaps")
+	}
+}
+
+func TestBitmap_EqualCopy(t *testing.T) {
+	a := New(128)
+	a.Set(3)
+
+	if a.Equal(New(128)) {
+		t.Fatal("did not expect a to equal an empty bitmap")
+	}
+	if !a.Equal(a.Copy()) {
+		t.Fatal("expected a bitmap to equal its own copy")
+	}
+
+	cp := a.Copy()
+	cp.Set(100)
+	if a.IsSet(100) {
+		t.Fatal("expected Copy to be independent of the original")
+	}
+	if a.Equal(cp) {
+		t.Fatal("did not expect a to equal a copy that was since modified")
+	}
+}
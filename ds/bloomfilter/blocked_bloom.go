@@ -0,0 +1,131 @@
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/liyue201/gostl/ds/bitmap"
+	"github.com/liyue201/gostl/utils/sync"
+	"math/bits"
+)
+
+// blockBits is the size in bits of a single block, matched to a typical 64-byte cache line
+const blockBits = 512
+
+// BlockedBloomFilter is a bloom filter variant that confines all k bits of a key to a single
+// blockBits-sized block, so Add/Contains touch one cache line instead of k scattered ones
+type BlockedBloomFilter struct {
+	m         uint64
+	k         uint64
+	numBlocks uint64
+	b         *bitmap.Bitmap
+	locker    sync.Locker
+	hasher    Hasher
+}
+
+// NewBlocked news a BlockedBloomFilter with m bits (rounded up to a whole number of blocks) and k hash functions
+func NewBlocked(m, k uint64, opts ...Options) *BlockedBloomFilter {
+	option := Option{
+		locker: defaultLocker,
+		hasher: defaultHasher{},
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	numBlocks := (m + blockBits - 1) / blockBits
+	if numBlocks == 0 {
+		numBlocks = 1
+	}
+	return &BlockedBloomFilter{
+		m:         numBlocks * blockBits,
+		k:         k,
+		numBlocks: numBlocks,
+		b:         bitmap.New(numBlocks * blockBits),
+		locker:    option.locker,
+		hasher:    option.hasher,
+	}
+}
+
+// NewBlockedWithEstimates news a BlockedBloomFilter with n and fp.
+// n is the capacity of the BlockedBloomFilter
+// fp is the tolerated error rate of the BlockedBloomFilter
+func NewBlockedWithEstimates(n uint64, fp float64, opts ...Options) *BlockedBloomFilter {
+	m, k := EstimateParameters(n, fp)
+	return NewBlocked(m, k, opts...)
+}
+
+// NewBlockedFromData news a BlockedBloomFilter by data passed, the data was generated by function 'Data()'
+func NewBlockedFromData(data []byte, opts ...Options) *BlockedBloomFilter {
+	option := Option{
+		locker: defaultLocker,
+		hasher: defaultHasher{},
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	bbf := &BlockedBloomFilter{
+		locker: option.locker,
+		hasher: option.hasher,
+	}
+	reader := bytes.NewReader(data)
+	binary.Read(reader, binary.LittleEndian, &bbf.m)
+	binary.Read(reader, binary.LittleEndian, &bbf.k)
+	binary.Read(reader, binary.LittleEndian, &bbf.numBlocks)
+	bbf.b = bitmap.NewFromData(data[8+8+8:])
+	return bbf
+}
+
+// blockAndPositions returns the block index for val and the k bit positions within that block.
+// Both are derived from a single 64-bit hash sum from the configured Hasher: the high 32 bits
+// pick the block, and the low 32 bits are split via Kirsch-Mitzenmacher double hashing into k
+// in-block positions, so Add/Contains pay for one hash sum and touch one cache line per lookup.
+func (bbf *BlockedBloomFilter) blockAndPositions(val string) (uint64, []uint64) {
+	h := bbf.hasher.Hash64([]byte(val))
+	block := (h >> 32) % bbf.numBlocks
+
+	h1 := uint32(h)
+	h2 := bits.RotateLeft32(h1, 16)
+	positions := make([]uint64, bbf.k)
+	for i := uint64(0); i < bbf.k; i++ {
+		positions[i] = (uint64(h1) + i*uint64(h2)) % blockBits
+	}
+	return block, positions
+}
+
+// Add adds a value to the BlockedBloomFilter
+func (bbf *BlockedBloomFilter) Add(val string) {
+	bbf.locker.Lock()
+	defer bbf.locker.Unlock()
+
+	block, positions := bbf.blockAndPositions(val)
+	for _, pos := range positions {
+		bbf.b.Set(block*blockBits + pos)
+	}
+}
+
+// Contains returns true if value passed is (high probability) in the BlockedBloomFilter, or false if not.
+func (bbf *BlockedBloomFilter) Contains(val string) bool {
+	bbf.locker.RLock()
+	defer bbf.locker.RUnlock()
+
+	block, positions := bbf.blockAndPositions(val)
+	for _, pos := range positions {
+		if !bbf.b.IsSet(block*blockBits + pos) {
+			return false
+		}
+	}
+	return true
+}
+
+// Data returns the data of BlockedBloomFilter, it can be used to new a BlockedBloomFilter by using
+// function 'NewBlockedFromData'.
+func (bbf *BlockedBloomFilter) Data() []byte {
+	bbf.locker.Lock()
+	defer bbf.locker.Unlock()
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, bbf.m)
+	binary.Write(buf, binary.LittleEndian, bbf.k)
+	binary.Write(buf, binary.LittleEndian, bbf.numBlocks)
+	buf.Write(bbf.b.Data())
+	return buf.Bytes()
+}
@@ -0,0 +1,44 @@
+package bloom
+
+import "testing"
+
+func TestBlockedBloomFilter_AddContains(t *testing.T) {
+	bbf := NewBlockedWithEstimates(1000, 0.01)
+
+	bbf.Add("hello")
+	if !bbf.Contains("hello") {
+		t.Fatal("expected hello to be contained")
+	}
+	if bbf.Contains("missing") {
+		t.Fatal("did not expect missing to be contained")
+	}
+}
+
+func TestBlockedBloomFilter_WithHasher(t *testing.T) {
+	bbf := NewBlocked(8192, 4, WithHasher(XXHasher()))
+
+	bbf.Add("hello")
+	if !bbf.Contains("hello") {
+		t.Fatal("expected hello to be contained")
+	}
+	if bbf.Contains("missing") {
+		t.Fatal("did not expect missing to be contained")
+	}
+}
+
+func TestBlockedBloomFilter_DataRoundTrip(t *testing.T) {
+	bbf := NewBlocked(8192, 4)
+	bbf.Add("hello")
+	bbf.Add("world")
+
+	restored := NewBlockedFromData(bbf.Data())
+	if !restored.Contains("hello") {
+		t.Fatal("expected hello to survive Data round trip")
+	}
+	if !restored.Contains("world") {
+		t.Fatal("expected world to survive Data round trip")
+	}
+	if restored.Contains("missing") {
+		t.Fatal("did not expect missing to be contained after round trip")
+	}
+}
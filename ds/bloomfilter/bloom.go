@@ -3,13 +3,17 @@ package bloom
 import (
 	"bytes"
 	"encoding/binary"
-	"github.com/liyue201/gostl/algorithm/hash"
+	"errors"
 	"github.com/liyue201/gostl/ds/bitmap"
 	"github.com/liyue201/gostl/utils/sync"
 	"math"
 	gosync "sync"
+	"unsafe"
 )
 
+// ErrMismatchedParameters is returned by Union/Intersect when the two filters don't share the same m and k
+var ErrMismatchedParameters = errors.New("bloom: filters have mismatched m/k")
+
 const Salt = "g9hmj2fhgr"
 
 var defaultLocker sync.FakeLocker
@@ -17,6 +21,7 @@ var defaultLocker sync.FakeLocker
 // BloomFilter's option
 type Option struct {
 	locker sync.Locker
+	hasher Hasher
 }
 
 type Options func(option *Option)
@@ -28,18 +33,28 @@ func WithThreadSave() Options {
 	}
 }
 
+// WithHasher configures the BloomFilter to derive its bit indices using h instead of the default
+// salted hash scheme
+func WithHasher(h Hasher) Options {
+	return func(option *Option) {
+		option.hasher = h
+	}
+}
+
 // BloomFilter is an implementation of bloom filter
 type BloomFilter struct {
 	m      uint64
 	k      uint64
 	b      *bitmap.Bitmap
 	locker sync.Locker
+	hasher Hasher
 }
 
 // New new a BloomFilter with m bits and k hash functions
 func New(m, k uint64, opts ...Options) *BloomFilter {
 	option := Option{
 		locker: defaultLocker,
+		hasher: defaultHasher{},
 	}
 	for _, opt := range opts {
 		opt(&option)
@@ -49,27 +64,30 @@ func New(m, k uint64, opts ...Options) *BloomFilter {
 		k:      k,
 		b:      bitmap.New(m),
 		locker: option.locker,
+		hasher: option.hasher,
 	}
 }
 
 // New new a BloomFilter with n and fp.
 // n is the capacity of the BloomFilter
 // fp is the tolerated error rate of the BloomFilter
-func NewWithEstimates(n uint64, fp float64) *BloomFilter {
+func NewWithEstimates(n uint64, fp float64, opts ...Options) *BloomFilter {
 	m, k := EstimateParameters(n, fp)
-	return New(m, k)
+	return New(m, k, opts...)
 }
 
-//NewFromData new a BloomFilter by data passed, the data was generated by function 'Data()'
+// NewFromData new a BloomFilter by data passed, the data was generated by function 'Data()'
 func NewFromData(data []byte, opts ...Options) *BloomFilter {
 	option := Option{
 		locker: defaultLocker,
+		hasher: defaultHasher{},
 	}
 	for _, opt := range opts {
 		opt(&option)
 	}
 	b := &BloomFilter{
 		locker: option.locker,
+		hasher: option.hasher,
 	}
 	reader := bytes.NewReader(data)
 	binary.Read(reader, binary.LittleEndian, &b.m)
@@ -87,29 +105,127 @@ func EstimateParameters(n uint64, p float64) (m uint64, k uint64) {
 
 // Add add a value to the BloomFilter
 func (bf *BloomFilter) Add(val string) {
+	bf.AddBytes([]byte(val))
+}
+
+// Contains returns true if value passed is (high probability) in the BloomFilter, or false if not.
+func (bf *BloomFilter) Contains(val string) bool {
+	return bf.ContainsBytes([]byte(val))
+}
+
+// AddBytes adds a []byte key to the BloomFilter, useful for binary keys such as hashes or UUIDs
+// that callers would otherwise need to stringify
+func (bf *BloomFilter) AddBytes(val []byte) {
 	bf.locker.Lock()
 	defer bf.locker.Unlock()
 
-	hashs := hash.GenHashInts([]byte(Salt+val), int(bf.k))
-	for i := uint64(0); i < bf.k; i++ {
-		bf.b.Set(hashs[i] % bf.m)
+	for _, idx := range bf.hasher.Indices(val, bf.k, bf.m) {
+		bf.b.Set(idx)
 	}
 }
 
-// Contains returns true if value passed is (high probability) in the BloomFilter, or false if not.
-func (bf *BloomFilter) Contains(val string) bool {
+// ContainsBytes returns true if the []byte key passed is (high probability) in the BloomFilter, or false if not.
+func (bf *BloomFilter) ContainsBytes(val []byte) bool {
 	bf.locker.RLock()
 	defer bf.locker.RUnlock()
 
-	hashs := hash.GenHashInts([]byte(Salt+val), int(bf.k))
-	for i := uint64(0); i < bf.k; i++ {
-		if !bf.b.IsSet(hashs[i] % bf.m) {
+	for _, idx := range bf.hasher.Indices(val, bf.k, bf.m) {
+		if !bf.b.IsSet(idx) {
 			return false
 		}
 	}
 	return true
 }
 
+// lockWriteRead locks bf for writing and other for reading, ordering the two acquisitions by
+// memory address so that concurrent a.Union(b)/b.Union(a) style calls can't deadlock on each
+// other's locks.
+func lockWriteRead(bf, other *BloomFilter) {
+	if uintptr(unsafe.Pointer(bf)) < uintptr(unsafe.Pointer(other)) {
+		bf.locker.Lock()
+		other.locker.RLock()
+	} else {
+		other.locker.RLock()
+		bf.locker.Lock()
+	}
+}
+
+func unlockWriteRead(bf, other *BloomFilter) {
+	bf.locker.Unlock()
+	other.locker.RUnlock()
+}
+
+// Union merges other into bf in place, so that bf contains every value either filter contained.
+// bf and other must have the same m and k.
+func (bf *BloomFilter) Union(other *BloomFilter) error {
+	if bf == other {
+		bf.locker.Lock()
+		defer bf.locker.Unlock()
+		return nil
+	}
+	lockWriteRead(bf, other)
+	defer unlockWriteRead(bf, other)
+
+	if bf.m != other.m || bf.k != other.k {
+		return ErrMismatchedParameters
+	}
+	bf.b.OrWith(other.b)
+	return nil
+}
+
+// Intersect keeps in bf only the values both bf and other may contain.
+// bf and other must have the same m and k.
+func (bf *BloomFilter) Intersect(other *BloomFilter) error {
+	if bf == other {
+		bf.locker.Lock()
+		defer bf.locker.Unlock()
+		return nil
+	}
+	lockWriteRead(bf, other)
+	defer unlockWriteRead(bf, other)
+
+	if bf.m != other.m || bf.k != other.k {
+		return ErrMismatchedParameters
+	}
+	bf.b.AndWith(other.b)
+	return nil
+}
+
+// Equal returns true if bf and other have the same m, k and underlying bits set
+func (bf *BloomFilter) Equal(other *BloomFilter) bool {
+	if bf == other {
+		return true
+	}
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+	other.locker.RLock()
+	defer other.locker.RUnlock()
+
+	if bf.m != other.m || bf.k != other.k {
+		return false
+	}
+	return bf.b.Equal(other.b)
+}
+
+// Copy returns a deep copy of bf, preserving whether it was created with WithThreadSave
+func (bf *BloomFilter) Copy() *BloomFilter {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	locker := sync.Locker(defaultLocker)
+	if _, threadSafe := bf.locker.(*gosync.RWMutex); threadSafe {
+		locker = &gosync.RWMutex{}
+	}
+
+	return &BloomFilter{
+		m:      bf.m,
+		k:      bf.k,
+		b:      bf.b.Copy(),
+		locker: locker,
+		hasher: bf.hasher,
+	}
+}
+
 // Contains returns the data of BloomFilter, it can bee used to new a BloomFilter by using function 'NewFromData' .
 func (bf *BloomFilter) Data() []byte {
 	bf.locker.Lock()
@@ -0,0 +1,108 @@
+package bloom
+
+import (
+	gosync "sync"
+	"testing"
+	"time"
+)
+
+func TestBloomFilter_AddContainsDataRoundTrip(t *testing.T) {
+	bf := New(1000, 4)
+	bf.Add("hello")
+
+	restored := NewFromData(bf.Data())
+	if !restored.Contains("hello") {
+		t.Fatal("expected hello to survive Data round trip")
+	}
+	if restored.Contains("missing") {
+		t.Fatal("did not expect missing to be contained after round trip")
+	}
+}
+
+func TestBloomFilter_UnionIntersectEqual(t *testing.T) {
+	a := New(1000, 4)
+	a.Add("hello")
+	b := New(1000, 4)
+	b.Add("world")
+
+	union := a.Copy()
+	if err := union.Union(b); err != nil {
+		t.Fatalf("unexpected Union error: %v", err)
+	}
+	if !union.Contains("hello") || !union.Contains("world") {
+		t.Fatal("expected union to contain both values")
+	}
+
+	intersect := a.Copy()
+	if err := intersect.Intersect(b); err != nil {
+		t.Fatalf("unexpected Intersect error: %v", err)
+	}
+	if intersect.Contains("hello") || intersect.Contains("world") {
+		t.Fatal("did not expect the intersection of disjoint filters to contain either value")
+	}
+
+	if a.Equal(b) {
+		t.Fatal("did not expect a and b to be equal")
+	}
+	if !a.Equal(a.Copy()) {
+		t.Fatal("expected a filter to equal its own copy")
+	}
+
+	mismatched := New(2000, 4)
+	if err := a.Union(mismatched); err != ErrMismatchedParameters {
+		t.Fatalf("expected ErrMismatchedParameters, got %v", err)
+	}
+}
+
+func TestBloomFilter_UnionSelfDoesNotDeadlock(t *testing.T) {
+	bf := New(1000, 4, WithThreadSave())
+	bf.Add("hello")
+
+	if err := bf.Union(bf); err != nil {
+		t.Fatalf("unexpected error on self-Union: %v", err)
+	}
+	if !bf.Equal(bf) {
+		t.Fatal("expected a filter to equal itself")
+	}
+}
+
+func TestBloomFilter_CrossUnionDoesNotDeadlock(t *testing.T) {
+	a := New(1000, 4, WithThreadSave())
+	b := New(1000, 4, WithThreadSave())
+	a.Add("hello")
+	b.Add("world")
+
+	var wg gosync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.Union(b)
+	}()
+	go func() {
+		defer wg.Done()
+		b.Union(a)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a.Union(b) and b.Union(a) deadlocked")
+	}
+}
+
+func TestBloomFilter_CopyPreservesThreadSafety(t *testing.T) {
+	safe := New(1000, 4, WithThreadSave())
+	if _, ok := safe.Copy().locker.(*gosync.RWMutex); !ok {
+		t.Fatal("expected Copy of a thread-safe filter to also be thread-safe")
+	}
+
+	plain := New(1000, 4)
+	if _, ok := plain.Copy().locker.(*gosync.RWMutex); ok {
+		t.Fatal("did not expect Copy of a non-thread-safe filter to become thread-safe")
+	}
+}
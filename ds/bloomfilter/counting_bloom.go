@@ -0,0 +1,231 @@
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/liyue201/gostl/algorithm/hash"
+	"github.com/liyue201/gostl/utils/sync"
+	gosync "sync"
+)
+
+// defaultBucketBits is the counter width used when CountingOption.bucketBits is not set
+const defaultBucketBits = 4
+
+// CountingOption is CountingBloomFilter's option
+type CountingOption struct {
+	locker     sync.Locker
+	bucketBits uint
+}
+
+type CountingOptions func(option *CountingOption)
+
+// WithCountingThreadSave use to config CountingBloomFilter with thread safety
+func WithCountingThreadSave() CountingOptions {
+	return func(option *CountingOption) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithBucketBits sets the width in bits of each counter. Only 4, 8 and 16 are supported;
+// any other value up to 16 is rounded up to the next one of those, and any value above 16
+// becomes 16. Wider counters tolerate more
+// Add/Remove churn on the same value before saturating, at the cost of more memory.
+func WithBucketBits(bits uint) CountingOptions {
+	return func(option *CountingOption) {
+		option.bucketBits = bits
+	}
+}
+
+// CountingBloomFilter is a bloom filter that supports Remove by replacing each bit
+// with a small saturating counter
+type CountingBloomFilter struct {
+	m          uint64
+	k          uint64
+	bucketBits uint8
+	maxCount   uint64
+	counters   []byte
+	locker     sync.Locker
+}
+
+// NewCounting news a CountingBloomFilter with m counters and k hash functions
+func NewCounting(m, k uint64, opts ...CountingOptions) *CountingBloomFilter {
+	option := CountingOption{
+		locker:     defaultLocker,
+		bucketBits: defaultBucketBits,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	bucketBits := normalizeBucketBits(option.bucketBits)
+	cbf := &CountingBloomFilter{
+		m:          m,
+		k:          k,
+		bucketBits: bucketBits,
+		maxCount:   1<<bucketBits - 1,
+		locker:     option.locker,
+	}
+	cbf.counters = make([]byte, counterBytes(m, cbf.bucketBits))
+	return cbf
+}
+
+// NewCountingWithEstimates news a CountingBloomFilter with n and fp.
+// n is the capacity of the CountingBloomFilter
+// fp is the tolerated error rate of the CountingBloomFilter
+func NewCountingWithEstimates(n uint64, fp float64, opts ...CountingOptions) *CountingBloomFilter {
+	m, k := EstimateParameters(n, fp)
+	return NewCounting(m, k, opts...)
+}
+
+// NewCountingFromData news a CountingBloomFilter by data passed, the data was generated by function 'Data()'
+func NewCountingFromData(data []byte, opts ...CountingOptions) *CountingBloomFilter {
+	option := CountingOption{
+		locker:     defaultLocker,
+		bucketBits: defaultBucketBits,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	cbf := &CountingBloomFilter{
+		locker: option.locker,
+	}
+	reader := bytes.NewReader(data)
+	binary.Read(reader, binary.LittleEndian, &cbf.m)
+	binary.Read(reader, binary.LittleEndian, &cbf.k)
+	binary.Read(reader, binary.LittleEndian, &cbf.bucketBits)
+	cbf.bucketBits = normalizeBucketBits(uint(cbf.bucketBits))
+	cbf.maxCount = 1<<cbf.bucketBits - 1
+	cbf.counters = make([]byte, counterBytes(cbf.m, cbf.bucketBits))
+	copy(cbf.counters, data[8+8+1:])
+	return cbf
+}
+
+// counterBytes returns the number of bytes needed to hold m counters of bucketBits each
+func counterBytes(m uint64, bucketBits uint8) uint64 {
+	return (m*uint64(bucketBits) + 7) / 8
+}
+
+// normalizeBucketBits rounds bits up to the next supported counter width (4, 8 or 16), clamping
+// values above 16 to 16, so a
+// misconfigured WithBucketBits can't slip through construction and panic later from getCounter/setCounter.
+func normalizeBucketBits(bits uint) uint8 {
+	switch {
+	case bits <= 4:
+		return 4
+	case bits <= 8:
+		return 8
+	default:
+		return 16
+	}
+}
+
+// getCounter returns the value of the counter at index i
+func (cbf *CountingBloomFilter) getCounter(i uint64) uint64 {
+	switch cbf.bucketBits {
+	case 4:
+		b := cbf.counters[i/2]
+		if i%2 == 0 {
+			return uint64(b & 0x0f)
+		}
+		return uint64(b >> 4)
+	case 8:
+		return uint64(cbf.counters[i])
+	case 16:
+		return uint64(binary.LittleEndian.Uint16(cbf.counters[i*2:]))
+	default:
+		panic("bloom: unsupported bucket bits")
+	}
+}
+
+// setCounter sets the value of the counter at index i
+func (cbf *CountingBloomFilter) setCounter(i uint64, v uint64) {
+	switch cbf.bucketBits {
+	case 4:
+		b := cbf.counters[i/2]
+		if i%2 == 0 {
+			cbf.counters[i/2] = (b & 0xf0) | byte(v&0x0f)
+		} else {
+			cbf.counters[i/2] = (b & 0x0f) | byte(v<<4)
+		}
+	case 8:
+		cbf.counters[i] = byte(v)
+	case 16:
+		binary.LittleEndian.PutUint16(cbf.counters[i*2:], uint16(v))
+	default:
+		panic("bloom: unsupported bucket bits")
+	}
+}
+
+func (cbf *CountingBloomFilter) hashIndexes(val string) []uint64 {
+	hashs := hash.GenHashInts([]byte(Salt+val), int(cbf.k))
+	indexes := make([]uint64, cbf.k)
+	for i := uint64(0); i < cbf.k; i++ {
+		indexes[i] = hashs[i] % cbf.m
+	}
+	return indexes
+}
+
+// Add adds a value to the CountingBloomFilter, incrementing the counter of each of its k positions
+func (cbf *CountingBloomFilter) Add(val string) {
+	cbf.locker.Lock()
+	defer cbf.locker.Unlock()
+
+	for _, idx := range cbf.hashIndexes(val) {
+		if c := cbf.getCounter(idx); c < cbf.maxCount {
+			cbf.setCounter(idx, c+1)
+		}
+	}
+}
+
+// Remove removes a value from the CountingBloomFilter, decrementing the counter of each of its k positions.
+// Removing a value that was never added may cause false negatives for other values sharing its positions.
+func (cbf *CountingBloomFilter) Remove(val string) {
+	cbf.locker.Lock()
+	defer cbf.locker.Unlock()
+
+	for _, idx := range cbf.hashIndexes(val) {
+		if c := cbf.getCounter(idx); c > 0 {
+			cbf.setCounter(idx, c-1)
+		}
+	}
+}
+
+// Contains returns true if value passed is (high probability) in the CountingBloomFilter, or false if not.
+func (cbf *CountingBloomFilter) Contains(val string) bool {
+	cbf.locker.RLock()
+	defer cbf.locker.RUnlock()
+
+	for _, idx := range cbf.hashIndexes(val) {
+		if cbf.getCounter(idx) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns an estimate of how many times val was added minus how many times it was removed,
+// taken as the minimum counter among its k positions
+func (cbf *CountingBloomFilter) Count(val string) uint64 {
+	cbf.locker.RLock()
+	defer cbf.locker.RUnlock()
+
+	min := cbf.maxCount
+	for _, idx := range cbf.hashIndexes(val) {
+		if c := cbf.getCounter(idx); c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+// Data returns the data of CountingBloomFilter, it can be used to new a CountingBloomFilter by using function 'NewCountingFromData'.
+func (cbf *CountingBloomFilter) Data() []byte {
+	cbf.locker.Lock()
+	defer cbf.locker.Unlock()
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, cbf.m)
+	binary.Write(buf, binary.LittleEndian, cbf.k)
+	binary.Write(buf, binary.LittleEndian, cbf.bucketBits)
+	buf.Write(cbf.counters)
+	return buf.Bytes()
+}
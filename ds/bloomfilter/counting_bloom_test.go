@@ -0,0 +1,72 @@
+package bloom
+
+import "testing"
+
+func TestCountingBloomFilter_AddRemoveContains(t *testing.T) {
+	cbf := NewCounting(1000, 4)
+
+	cbf.Add("hello")
+	cbf.Add("world")
+
+	if !cbf.Contains("hello") {
+		t.Fatal("expected hello to be contained")
+	}
+	if !cbf.Contains("world") {
+		t.Fatal("expected world to be contained")
+	}
+	if cbf.Contains("missing") {
+		t.Fatal("did not expect missing to be contained")
+	}
+
+	cbf.Remove("hello")
+	if cbf.Contains("hello") {
+		t.Fatal("did not expect hello to be contained after Remove")
+	}
+	if !cbf.Contains("world") {
+		t.Fatal("expected world to still be contained")
+	}
+}
+
+func TestCountingBloomFilter_Count(t *testing.T) {
+	cbf := NewCounting(1000, 4)
+
+	cbf.Add("hello")
+	cbf.Add("hello")
+	if count := cbf.Count("hello"); count != 2 {
+		t.Fatalf("expected count 2, got %d", count)
+	}
+
+	cbf.Remove("hello")
+	if count := cbf.Count("hello"); count != 1 {
+		t.Fatalf("expected count 1, got %d", count)
+	}
+}
+
+func TestCountingBloomFilter_DataRoundTrip(t *testing.T) {
+	cbf := NewCounting(1000, 4, WithBucketBits(8))
+	cbf.Add("hello")
+	cbf.Add("world")
+
+	restored := NewCountingFromData(cbf.Data())
+	if !restored.Contains("hello") {
+		t.Fatal("expected hello to survive Data round trip")
+	}
+	if !restored.Contains("world") {
+		t.Fatal("expected world to survive Data round trip")
+	}
+	if restored.Contains("missing") {
+		t.Fatal("did not expect missing to be contained after round trip")
+	}
+}
+
+func TestCountingBloomFilter_BucketBitsClamped(t *testing.T) {
+	cbf := NewCounting(1000, 4, WithBucketBits(5))
+	if cbf.bucketBits != 8 {
+		t.Fatalf("expected bucketBits 5 to clamp to 8, got %d", cbf.bucketBits)
+	}
+
+	cbf.Add("hello")
+	if !cbf.Contains("hello") {
+		t.Fatal("expected hello to be contained")
+	}
+}
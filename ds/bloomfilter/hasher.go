@@ -0,0 +1,89 @@
+package bloom
+
+import (
+	"github.com/cespare/xxhash/v2"
+	"github.com/liyue201/gostl/algorithm/hash"
+	"github.com/spaolacci/murmur3"
+)
+
+// Hasher derives the k bit indices in [0, m) for a key, letting callers plug in a hash function
+// appropriate to their workload
+type Hasher interface {
+	Indices(data []byte, k, m uint64) []uint64
+
+	// Hash64 returns a single 64-bit hash sum for data, for callers like BlockedBloomFilter that
+	// need to split one hash into a block selector and in-block bit positions themselves rather
+	// than paying for a second hash sum per Indices call
+	Hash64(data []byte) uint64
+}
+
+// defaultHasher reproduces the original salted hash.GenHashInts scheme, kept as the default for
+// backward compatibility with filters created before Hasher existed
+type defaultHasher struct{}
+
+func (defaultHasher) Indices(data []byte, k, m uint64) []uint64 {
+	hashs := hash.GenHashInts(append([]byte(Salt), data...), int(k))
+	indices := make([]uint64, k)
+	for i := uint64(0); i < k; i++ {
+		indices[i] = hashs[i] % m
+	}
+	return indices
+}
+
+func (defaultHasher) Hash64(data []byte) uint64 {
+	return hash.GenHashInts(append([]byte(Salt), data...), 1)[0]
+}
+
+// doubleHash derives k indices from two independent 64-bit hashes using the Kirsch-Mitzenmacher
+// technique: g_i(x) = h1(x) + i*h2(x)
+func doubleHash(h1, h2, k, m uint64) []uint64 {
+	indices := make([]uint64, k)
+	for i := uint64(0); i < k; i++ {
+		indices[i] = (h1 + i*h2) % m
+	}
+	return indices
+}
+
+// xxhashHasher derives its k indices from two xxhash64 sums (of the key, and of the key with one
+// byte of the first sum appended) via double hashing
+type xxhashHasher struct{}
+
+// XXHasher is a Hasher backed by xxhash, faster than the default scheme for larger keys
+func XXHasher() Hasher {
+	return xxhashHasher{}
+}
+
+func (xxhashHasher) Indices(data []byte, k, m uint64) []uint64 {
+	h1 := xxhash.Sum64(data)
+
+	// derive h2 from a copy so we never write into data's backing array, which the
+	// caller may still own a larger slice over (e.g. a sub-slice key)
+	seeded := make([]byte, len(data)+1)
+	copy(seeded, data)
+	seeded[len(data)] = byte(h1)
+	h2 := xxhash.Sum64(seeded)
+
+	return doubleHash(h1, h2, k, m)
+}
+
+func (xxhashHasher) Hash64(data []byte) uint64 {
+	return xxhash.Sum64(data)
+}
+
+// murmur3Hasher derives its k indices from a murmur3 128-bit sum via double hashing
+type murmur3Hasher struct{}
+
+// Murmur3Hasher is a Hasher backed by murmur3
+func Murmur3Hasher() Hasher {
+	return murmur3Hasher{}
+}
+
+func (murmur3Hasher) Indices(data []byte, k, m uint64) []uint64 {
+	h1, h2 := murmur3.Sum128(data)
+	return doubleHash(h1, h2, k, m)
+}
+
+func (murmur3Hasher) Hash64(data []byte) uint64 {
+	h1, _ := murmur3.Sum128(data)
+	return h1
+}
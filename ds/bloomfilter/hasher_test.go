@@ -0,0 +1,42 @@
+package bloom
+
+import "testing"
+
+func TestBloomFilter_AddBytesContainsBytesWithHashers(t *testing.T) {
+	hashers := map[string]Hasher{
+		"default": defaultHasher{},
+		"xxhash":  XXHasher(),
+		"murmur3": Murmur3Hasher(),
+	}
+	for name, hasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			bf := New(1000, 4, WithHasher(hasher))
+			key := []byte{0xde, 0xad, 0xbe, 0xef}
+
+			bf.AddBytes(key)
+			if !bf.ContainsBytes(key) {
+				t.Fatalf("expected key to be contained with %s hasher", name)
+			}
+			if bf.ContainsBytes([]byte{0x01, 0x02, 0x03}) {
+				t.Fatalf("did not expect unrelated key to be contained with %s hasher", name)
+			}
+		})
+	}
+}
+
+func TestXXHasher_DoesNotMutateInputSlice(t *testing.T) {
+	// backing array with spare capacity, as callers passing a sub-slice key would have
+	backing := make([]byte, 4, 16)
+	copy(backing, []byte{1, 2, 3, 4})
+	key := backing[:4]
+
+	before := append([]byte{}, backing[:cap(backing)]...)
+	XXHasher().Indices(key, 4, 1000)
+
+	after := backing[:cap(backing)]
+	for i := range before {
+		if before[i] != after[i] {
+			t.Fatalf("xxhash hasher mutated caller's backing array at index %d: before=%v after=%v", i, before, after)
+		}
+	}
+}
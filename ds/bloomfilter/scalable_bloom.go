@@ -0,0 +1,187 @@
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/liyue201/gostl/utils/sync"
+	"math"
+)
+
+const (
+	// defaultGrowth is the factor by which each new inner filter's m grows over the previous one
+	defaultGrowth = 2
+	// defaultTightenRatio is the factor by which each new inner filter's fp tightens over the previous one
+	defaultTightenRatio = 0.9
+	// defaultFillRatio is the fraction of the active inner filter's item capacity that may be added
+	// before it is considered full; the number of set bits is not consulted
+	defaultFillRatio = 0.5
+)
+
+// ScalableBloomFilter is a bloom filter that grows by adding new inner BloomFilters as it fills up,
+// so callers don't need to know the final capacity up front. Its overall false positive rate is
+// bounded by p0/(1-r), where p0 is the initial fp and r is the tighten ratio.
+type ScalableBloomFilter struct {
+	n            uint64
+	fp           float64
+	growth       uint64
+	tightenRatio float64
+	fillRatio    float64
+	filters      []*BloomFilter
+	caps         []uint64
+	counts       []uint64
+	opts         []Options
+	locker       sync.Locker
+}
+
+// NewScalable news a ScalableBloomFilter whose first inner filter is sized for initialN items at the
+// given false positive rate fp
+func NewScalable(initialN uint64, fp float64, opts ...Options) *ScalableBloomFilter {
+	option := Option{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	sbf := &ScalableBloomFilter{
+		n:            initialN,
+		fp:           fp,
+		growth:       defaultGrowth,
+		tightenRatio: defaultTightenRatio,
+		fillRatio:    defaultFillRatio,
+		opts:         opts,
+		locker:       option.locker,
+	}
+	sbf.filters = append(sbf.filters, NewWithEstimates(initialN, fp, opts...))
+	sbf.caps = append(sbf.caps, initialN)
+	sbf.counts = append(sbf.counts, 0)
+	return sbf
+}
+
+// NewScalableFromData news a ScalableBloomFilter by data passed, the data was generated by function 'Data()'
+func NewScalableFromData(data []byte, opts ...Options) *ScalableBloomFilter {
+	option := Option{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	sbf := &ScalableBloomFilter{
+		growth:       defaultGrowth,
+		tightenRatio: defaultTightenRatio,
+		fillRatio:    defaultFillRatio,
+		opts:         opts,
+		locker:       option.locker,
+	}
+	reader := bytes.NewReader(data)
+	binary.Read(reader, binary.LittleEndian, &sbf.fp)
+	binary.Read(reader, binary.LittleEndian, &sbf.growth)
+	binary.Read(reader, binary.LittleEndian, &sbf.tightenRatio)
+	binary.Read(reader, binary.LittleEndian, &sbf.fillRatio)
+	var num uint64
+	binary.Read(reader, binary.LittleEndian, &num)
+	for i := uint64(0); i < num; i++ {
+		var filterCap, cnt, size uint64
+		binary.Read(reader, binary.LittleEndian, &filterCap)
+		binary.Read(reader, binary.LittleEndian, &cnt)
+		binary.Read(reader, binary.LittleEndian, &size)
+		buf := make([]byte, size)
+		reader.Read(buf)
+		sbf.filters = append(sbf.filters, NewFromData(buf, opts...))
+		sbf.caps = append(sbf.caps, filterCap)
+		sbf.counts = append(sbf.counts, cnt)
+	}
+	if len(sbf.caps) > 0 {
+		sbf.n = sbf.caps[0]
+	}
+	return sbf
+}
+
+// active returns the inner filter currently receiving new items
+func (sbf *ScalableBloomFilter) active() *BloomFilter {
+	return sbf.filters[len(sbf.filters)-1]
+}
+
+// full returns true if the active filter holds at least as many items as its fill ratio threshold allows
+func (sbf *ScalableBloomFilter) full() bool {
+	last := len(sbf.filters) - 1
+	return float64(sbf.counts[last]) >= float64(sbf.caps[last])*sbf.fillRatio
+}
+
+// grow appends a new, larger and tighter inner filter and makes it the active one
+func (sbf *ScalableBloomFilter) grow() {
+	i := uint64(len(sbf.filters))
+	m := sbf.filters[0].m * pow(sbf.growth, i)
+	fp := sbf.fp * powf(sbf.tightenRatio, i)
+	// the item capacity and k that m bits support at fp, inverting EstimateParameters
+	n := uint64(float64(m) * math.Ln2 * math.Ln2 / -math.Log(fp))
+	if n == 0 {
+		n = 1
+	}
+	k := uint64(math.Ceil(math.Ln2 * float64(m) / float64(n)))
+	sbf.filters = append(sbf.filters, New(m, k, sbf.opts...))
+	sbf.caps = append(sbf.caps, n)
+	sbf.counts = append(sbf.counts, 0)
+}
+
+func pow(base, exp uint64) uint64 {
+	r := uint64(1)
+	for i := uint64(0); i < exp; i++ {
+		r *= base
+	}
+	return r
+}
+
+func powf(base float64, exp uint64) float64 {
+	r := 1.0
+	for i := uint64(0); i < exp; i++ {
+		r *= base
+	}
+	return r
+}
+
+// Add adds a value to the ScalableBloomFilter, growing a new inner filter first if the active one is full
+func (sbf *ScalableBloomFilter) Add(val string) {
+	sbf.locker.Lock()
+	defer sbf.locker.Unlock()
+
+	if sbf.full() {
+		sbf.grow()
+	}
+	sbf.active().Add(val)
+	sbf.counts[len(sbf.counts)-1]++
+}
+
+// Contains returns true if value passed is (high probability) in any of the inner filters, or false if not.
+func (sbf *ScalableBloomFilter) Contains(val string) bool {
+	sbf.locker.RLock()
+	defer sbf.locker.RUnlock()
+
+	for _, f := range sbf.filters {
+		if f.Contains(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// Data returns the data of ScalableBloomFilter, it can be used to new a ScalableBloomFilter by using
+// function 'NewScalableFromData'.
+func (sbf *ScalableBloomFilter) Data() []byte {
+	sbf.locker.Lock()
+	defer sbf.locker.Unlock()
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, sbf.fp)
+	binary.Write(buf, binary.LittleEndian, sbf.growth)
+	binary.Write(buf, binary.LittleEndian, sbf.tightenRatio)
+	binary.Write(buf, binary.LittleEndian, sbf.fillRatio)
+	binary.Write(buf, binary.LittleEndian, uint64(len(sbf.filters)))
+	for i, f := range sbf.filters {
+		data := f.Data()
+		binary.Write(buf, binary.LittleEndian, sbf.caps[i])
+		binary.Write(buf, binary.LittleEndian, sbf.counts[i])
+		binary.Write(buf, binary.LittleEndian, uint64(len(data)))
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
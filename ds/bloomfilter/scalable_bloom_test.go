@@ -0,0 +1,54 @@
+package bloom
+
+import "testing"
+
+func TestScalableBloomFilter_AddContains(t *testing.T) {
+	sbf := NewScalable(10, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		sbf.Add(string(rune(i)))
+	}
+	for i := 0; i < 1000; i++ {
+		if !sbf.Contains(string(rune(i))) {
+			t.Fatalf("expected item %d to be contained after growth", i)
+		}
+	}
+	if len(sbf.filters) <= 1 {
+		t.Fatal("expected the filter to have grown past its initial inner filter")
+	}
+}
+
+func TestScalableBloomFilter_DataRoundTripWithHasher(t *testing.T) {
+	sbf := NewScalable(10, 0.01, WithHasher(XXHasher()))
+
+	for i := 0; i < 200; i++ {
+		sbf.Add(string(rune(i)))
+	}
+
+	restored := NewScalableFromData(sbf.Data(), WithHasher(XXHasher()))
+	for i := 0; i < 200; i++ {
+		if !restored.Contains(string(rune(i))) {
+			t.Fatalf("expected item %d to survive Data round trip with custom hasher", i)
+		}
+	}
+}
+
+func TestScalableBloomFilter_GrowsAfterDataRoundTrip(t *testing.T) {
+	sbf := NewScalable(10, 0.01)
+	for i := 0; i < 10; i++ {
+		sbf.Add(string(rune(i)))
+	}
+
+	restored := NewScalableFromData(sbf.Data())
+	for i := 10; i < 1000; i++ {
+		restored.Add(string(rune(i)))
+	}
+	for i := 0; i < 1000; i++ {
+		if !restored.Contains(string(rune(i))) {
+			t.Fatalf("expected item %d to be contained after growth past a Data round trip", i)
+		}
+	}
+	if len(restored.filters) <= 1 {
+		t.Fatal("expected the restored filter to have grown past its initial inner filter")
+	}
+}
@@ -0,0 +1,296 @@
+// Package cuckoo implements a cuckoo filter, a probabilistic set membership structure like
+// bloom.BloomFilter that additionally supports deletion and offers better space efficiency at
+// low false positive rates.
+package cuckoo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/liyue201/gostl/algorithm/hash"
+	"github.com/liyue201/gostl/utils/sync"
+	"math"
+	gosync "sync"
+)
+
+const (
+	// bucketSize is the number of fingerprint slots per bucket
+	bucketSize = 4
+	// minFingerprintBytes and maxFingerprintBytes bound the fingerprint width New() derives from fp,
+	// keeping it within the 8-16 bit range classic cuckoo filter implementations use
+	minFingerprintBytes = 1
+	maxFingerprintBytes = 2
+	// maxKicks is the number of relocation attempts before Insert gives up and reports the table full
+	maxKicks = 500
+)
+
+var defaultLocker sync.FakeLocker
+
+// Option is CuckooFilter's option
+type Option struct {
+	locker sync.Locker
+}
+
+type Options func(option *Option)
+
+// WithThreadSave use to config CuckooFilter with thread safety
+func WithThreadSave() Options {
+	return func(option *Option) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// CuckooFilter is an implementation of a cuckoo filter
+type CuckooFilter struct {
+	numBuckets uint64
+	count      uint64
+	fpBytes    uint64
+	buckets    [][]byte
+	locker     sync.Locker
+}
+
+// New news a CuckooFilter sized to hold capacity items at the given false positive rate fp.
+// fp determines the fingerprint width in bytes (1 or 2, i.e. 8-16 bits): the false positive rate
+// of a cuckoo filter is roughly 2*bucketSize/2^f, so a smaller fp needs a wider fingerprint.
+func New(capacity uint64, fp float64, opts ...Options) *CuckooFilter {
+	option := Option{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	numBuckets := nextPowerOfTwo((capacity + bucketSize - 1) / bucketSize)
+	if numBuckets == 0 {
+		numBuckets = 1
+	}
+	fpBytes := fingerprintBytesFor(fp)
+	cf := &CuckooFilter{
+		numBuckets: numBuckets,
+		fpBytes:    fpBytes,
+		locker:     option.locker,
+	}
+	cf.buckets = make([][]byte, numBuckets)
+	for i := range cf.buckets {
+		cf.buckets[i] = make([]byte, 0, bucketSize*fpBytes)
+	}
+	return cf
+}
+
+// fingerprintBytesFor returns the fingerprint width in bytes needed to keep the false positive
+// rate around fp, given bucketSize slots per bucket, clamped to [minFingerprintBytes, maxFingerprintBytes].
+func fingerprintBytesFor(fp float64) uint64 {
+	bits := math.Log2(2 * bucketSize / fp)
+	n := uint64(math.Ceil(bits / 8))
+	if n < minFingerprintBytes {
+		n = minFingerprintBytes
+	}
+	if n > maxFingerprintBytes {
+		n = maxFingerprintBytes
+	}
+	return n
+}
+
+// NewFromData news a CuckooFilter by data passed, the data was generated by function 'Data()'
+func NewFromData(data []byte, opts ...Options) *CuckooFilter {
+	option := Option{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	cf := &CuckooFilter{
+		locker: option.locker,
+	}
+	reader := bytes.NewReader(data)
+	binary.Read(reader, binary.LittleEndian, &cf.numBuckets)
+	binary.Read(reader, binary.LittleEndian, &cf.count)
+	binary.Read(reader, binary.LittleEndian, &cf.fpBytes)
+	cf.buckets = make([][]byte, cf.numBuckets)
+	for i := range cf.buckets {
+		var size uint64
+		binary.Read(reader, binary.LittleEndian, &size)
+		buf := make([]byte, size)
+		reader.Read(buf)
+		cf.buckets[i] = buf
+	}
+	return cf
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// fingerprintAndIndex derives the fingerprint and primary bucket index for key x
+func (cf *CuckooFilter) fingerprintAndIndex(x []byte) (uint64, uint64) {
+	hashs := hash.GenHashInts(x, 1)
+	h := hashs[0]
+	f := (h >> (64 - cf.fpBytes*8)) & (1<<(cf.fpBytes*8) - 1)
+	if f == 0 {
+		f = 1
+	}
+	i1 := h % cf.numBuckets
+	return f, i1
+}
+
+// altIndex returns the alternate bucket index for index i holding fingerprint f
+func (cf *CuckooFilter) altIndex(i uint64, f uint64) uint64 {
+	hashs := hash.GenHashInts(cf.encodeFingerprint(f), 1)
+	return (i ^ hashs[0]) % cf.numBuckets
+}
+
+// encodeFingerprint renders fingerprint f as its cf.fpBytes little-endian byte representation
+func (cf *CuckooFilter) encodeFingerprint(f uint64) []byte {
+	buf := make([]byte, cf.fpBytes)
+	for i := uint64(0); i < cf.fpBytes; i++ {
+		buf[i] = byte(f >> (8 * i))
+	}
+	return buf
+}
+
+// decodeFingerprint reads a fingerprint out of a bucket's byte slice at the given slot
+func (cf *CuckooFilter) decodeFingerprint(bucket []byte, slot int) uint64 {
+	var f uint64
+	for i := uint64(0); i < cf.fpBytes; i++ {
+		f |= uint64(bucket[uint64(slot)*cf.fpBytes+i]) << (8 * i)
+	}
+	return f
+}
+
+func (cf *CuckooFilter) bucketHas(bucket []byte, f uint64) (int, bool) {
+	slots := uint64(len(bucket)) / cf.fpBytes
+	for slot := uint64(0); slot < slots; slot++ {
+		if cf.decodeFingerprint(bucket, int(slot)) == f {
+			return int(slot), true
+		}
+	}
+	return -1, false
+}
+
+// Insert adds x to the CuckooFilter, returning false if the table is full and x could not be placed
+// after maxKicks relocation attempts.
+func (cf *CuckooFilter) Insert(x []byte) bool {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	f, i1 := cf.fingerprintAndIndex(x)
+	i2 := cf.altIndex(i1, f)
+
+	if cf.insertAt(i1, f) || cf.insertAt(i2, f) {
+		cf.count++
+		return true
+	}
+
+	i := i1
+	if pseudoRand(i1, i2)%2 == 1 {
+		i = i2
+	}
+	type displaced struct {
+		i    uint64
+		slot int
+		f    uint64
+	}
+	path := make([]displaced, 0, maxKicks)
+	for kick := 0; kick < maxKicks; kick++ {
+		bucket := cf.buckets[i]
+		slots := uint64(len(bucket)) / cf.fpBytes
+		slot := int(pseudoRand(i, uint64(kick)) % slots)
+		evicted := cf.decodeFingerprint(bucket, slot)
+		copy(bucket[uint64(slot)*cf.fpBytes:], cf.encodeFingerprint(f))
+		path = append(path, displaced{i, slot, evicted})
+		f = evicted
+		i = cf.altIndex(i, f)
+		if cf.insertAt(i, f) {
+			cf.count++
+			return true
+		}
+	}
+	// undo the relocations so the table is left as it was before the call
+	for j := len(path) - 1; j >= 0; j-- {
+		p := path[j]
+		copy(cf.buckets[p.i][uint64(p.slot)*cf.fpBytes:], cf.encodeFingerprint(p.f))
+	}
+	return false
+}
+
+// insertAt appends fingerprint f to the bucket at index i if it has a free slot
+func (cf *CuckooFilter) insertAt(i uint64, f uint64) bool {
+	if uint64(len(cf.buckets[i])) >= bucketSize*cf.fpBytes {
+		return false
+	}
+	cf.buckets[i] = append(cf.buckets[i], cf.encodeFingerprint(f)...)
+	return true
+}
+
+func pseudoRand(a, b uint64) uint64 {
+	return a*2654435761 + b
+}
+
+// Lookup returns true if x is (high probability) in the CuckooFilter, or false if not.
+func (cf *CuckooFilter) Lookup(x []byte) bool {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	f, i1 := cf.fingerprintAndIndex(x)
+	i2 := cf.altIndex(i1, f)
+	if _, ok := cf.bucketHas(cf.buckets[i1], f); ok {
+		return true
+	}
+	_, ok := cf.bucketHas(cf.buckets[i2], f)
+	return ok
+}
+
+// Delete removes one occurrence of x from the CuckooFilter, returning false if x was not found.
+func (cf *CuckooFilter) Delete(x []byte) bool {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	f, i1 := cf.fingerprintAndIndex(x)
+	if cf.removeFrom(i1, f) {
+		cf.count--
+		return true
+	}
+	i2 := cf.altIndex(i1, f)
+	if cf.removeFrom(i2, f) {
+		cf.count--
+		return true
+	}
+	return false
+}
+
+func (cf *CuckooFilter) removeFrom(i uint64, f uint64) bool {
+	bucket := cf.buckets[i]
+	slot, ok := cf.bucketHas(bucket, f)
+	if !ok {
+		return false
+	}
+	start := uint64(slot) * cf.fpBytes
+	cf.buckets[i] = append(bucket[:start], bucket[start+cf.fpBytes:]...)
+	return true
+}
+
+// Count returns the number of items currently stored in the CuckooFilter
+func (cf *CuckooFilter) Count() uint64 {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	return cf.count
+}
+
+// Data returns the data of CuckooFilter, it can be used to new a CuckooFilter by using function 'NewFromData'.
+func (cf *CuckooFilter) Data() []byte {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, cf.numBuckets)
+	binary.Write(buf, binary.LittleEndian, cf.count)
+	binary.Write(buf, binary.LittleEndian, cf.fpBytes)
+	for _, bucket := range cf.buckets {
+		binary.Write(buf, binary.LittleEndian, uint64(len(bucket)))
+		buf.Write(bucket)
+	}
+	return buf.Bytes()
+}
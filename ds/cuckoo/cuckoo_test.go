@@ -0,0 +1,59 @@
+package cuckoo
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCuckooFilter_InsertLookupDelete(t *testing.T) {
+	cf := New(1000, 0.01)
+
+	if !cf.Insert([]byte("hello")) {
+		t.Fatal("expected Insert to succeed")
+	}
+	if !cf.Lookup([]byte("hello")) {
+		t.Fatal("expected hello to be found")
+	}
+	if cf.Lookup([]byte("missing")) {
+		t.Fatal("did not expect missing to be found")
+	}
+	if cf.Count() != 1 {
+		t.Fatalf("expected count 1, got %d", cf.Count())
+	}
+
+	if !cf.Delete([]byte("hello")) {
+		t.Fatal("expected Delete to succeed")
+	}
+	if cf.Lookup([]byte("hello")) {
+		t.Fatal("did not expect hello to be found after Delete")
+	}
+	if cf.Count() != 0 {
+		t.Fatalf("expected count 0, got %d", cf.Count())
+	}
+}
+
+func TestCuckooFilter_FingerprintWidthScalesWithFP(t *testing.T) {
+	loose := New(1000, 0.1)
+	tight := New(1000, 0.0001)
+
+	if loose.fpBytes >= tight.fpBytes {
+		t.Fatalf("expected a tighter fp to need a wider fingerprint, got loose=%d tight=%d", loose.fpBytes, tight.fpBytes)
+	}
+}
+
+func TestCuckooFilter_DataRoundTrip(t *testing.T) {
+	cf := New(1000, 0.001)
+	for i := 0; i < 100; i++ {
+		cf.Insert([]byte(strconv.Itoa(i)))
+	}
+
+	restored := NewFromData(cf.Data())
+	for i := 0; i < 100; i++ {
+		if !restored.Lookup([]byte(strconv.Itoa(i))) {
+			t.Fatalf("expected item %d to survive Data round trip", i)
+		}
+	}
+	if restored.Count() != cf.Count() {
+		t.Fatalf("expected count %d, got %d", cf.Count(), restored.Count())
+	}
+}